@@ -0,0 +1,61 @@
+package starkkey
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrMissingPublicKey is returned by NewLedger when no public key was read back from the
+// device.
+var ErrMissingPublicKey = errors.New("starkkey: missing public key")
+
+// NewLedger constructs a Key backed by a Ledger hardware wallet: it carries the stark-curve
+// public key and the BIP32-like derivation path used to obtain it from the device, but no
+// private scalar. ToPrivKey on the returned Key is nil; signing for it must go through an
+// external signer that can talk to the device (see keystore.StarknetLedgerSigner).
+func NewLedger(derivationPath string, publicKey *big.Int) (Key, error) {
+	if publicKey == nil {
+		return Key{}, ErrMissingPublicKey
+	}
+	return Key{
+		priv:       nil,
+		pub:        publicKey,
+		ledgerPath: derivationPath,
+	}, nil
+}
+
+// IsLedger reports whether k is backed by a Ledger device rather than a software-held
+// private scalar.
+func (k Key) IsLedger() bool {
+	return k.priv == nil && k.pub != nil
+}
+
+// LedgerPath returns the BIP32-like derivation path a Ledger-backed Key was created with. It
+// is empty for software keys.
+func (k Key) LedgerPath() string {
+	return k.ledgerPath
+}
+
+// LedgerKeyData is the JSON-serializable form of a Ledger-backed Key. Ledger keys have no
+// private scalar, so they can't round-trip through ToEncryptedJSON/FromEncryptedJSON like a
+// software key; callers that need to persist one (see keystore.backendStarknet) marshal this
+// instead.
+type LedgerKeyData struct {
+	PublicKey      *big.Int `json:"publicKey"`
+	DerivationPath string   `json:"derivationPath"`
+}
+
+// ToLedgerData returns k's JSON-serializable Ledger representation. Callers must check
+// IsLedger first; ToLedgerData panics on a software key, since it would silently drop the
+// private scalar.
+func (k Key) ToLedgerData() LedgerKeyData {
+	if !k.IsLedger() {
+		panic("starkkey: ToLedgerData called on a non-Ledger key")
+	}
+	return LedgerKeyData{PublicKey: k.pub, DerivationPath: k.ledgerPath}
+}
+
+// LedgerFromData reconstructs the Key a LedgerKeyData was derived from.
+func LedgerFromData(d LedgerKeyData) (Key, error) {
+	return NewLedger(d.DerivationPath, d.PublicKey)
+}
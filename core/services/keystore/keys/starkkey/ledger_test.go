@@ -0,0 +1,57 @@
+package starkkey
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLedger(t *testing.T) {
+	t.Run("missing public key", func(t *testing.T) {
+		_, err := NewLedger("44'/9004'/0'/0/0", nil)
+		assert.ErrorIs(t, err, ErrMissingPublicKey)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		pub := big.NewInt(123)
+		key, err := NewLedger("44'/9004'/0'/0/0", pub)
+		require.NoError(t, err)
+		assert.True(t, key.IsLedger())
+		assert.Equal(t, "44'/9004'/0'/0/0", key.LedgerPath())
+		assert.Nil(t, key.ToPrivKey())
+	})
+}
+
+func TestKey_IsLedger(t *testing.T) {
+	ledgerKey, err := NewLedger("44'/9004'/0'/0/0", big.NewInt(1))
+	require.NoError(t, err)
+	assert.True(t, ledgerKey.IsLedger())
+
+	softwareKey, err := New()
+	require.NoError(t, err)
+	assert.False(t, softwareKey.IsLedger())
+}
+
+func TestLedgerKeyData_RoundTrip(t *testing.T) {
+	pub := big.NewInt(98765)
+	original, err := NewLedger("44'/9004'/0'/0/3", pub)
+	require.NoError(t, err)
+
+	data := original.ToLedgerData()
+	assert.Equal(t, pub, data.PublicKey)
+	assert.Equal(t, "44'/9004'/0'/0/3", data.DerivationPath)
+
+	restored, err := LedgerFromData(data)
+	require.NoError(t, err)
+	assert.True(t, restored.IsLedger())
+	assert.Equal(t, original.ID(), restored.ID())
+	assert.Equal(t, original.LedgerPath(), restored.LedgerPath())
+}
+
+func TestKey_ToLedgerData_PanicsOnSoftwareKey(t *testing.T) {
+	softwareKey, err := New()
+	require.NoError(t, err)
+	assert.Panics(t, func() { softwareKey.ToLedgerData() })
+}
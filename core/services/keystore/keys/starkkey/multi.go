@@ -0,0 +1,46 @@
+package starkkey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// MultiKey composes N stark-curve public keys with a signing threshold, mirroring the Cosmos
+// SDK's migration from LegacyMultiInfo to Record.Multi. It never holds private key material:
+// callers assemble the aggregate signature out-of-band from whichever constituent keys they
+// hold individually.
+type MultiKey struct {
+	Threshold  int
+	PublicKeys []*big.Int
+}
+
+// NewMultiKey constructs a MultiKey from pubs with the given signing threshold. threshold
+// must be between 1 and len(pubs) inclusive.
+func NewMultiKey(threshold int, pubs []*big.Int) (MultiKey, error) {
+	if threshold < 1 || threshold > len(pubs) {
+		return MultiKey{}, fmt.Errorf("starkkey: invalid multisig threshold %d for %d keys", threshold, len(pubs))
+	}
+	cp := make([]*big.Int, len(pubs))
+	copy(cp, pubs)
+	return MultiKey{Threshold: threshold, PublicKeys: cp}, nil
+}
+
+// ID deterministically identifies a MultiKey by hashing its threshold and ordered public
+// keys, the same way a software Key is identified by its public key.
+func (k MultiKey) ID() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:", k.Threshold)
+	for _, pub := range k.PublicKeys {
+		h.Write(pub.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IDFromPublicKey returns the Key ID that a software or Ledger key with the given public key
+// would have, without needing to hold the corresponding Key itself. Multisig signing uses
+// this to check whether a constituent of a MultiKey is held locally.
+func IDFromPublicKey(pub *big.Int) string {
+	return Key{pub: pub}.ID()
+}
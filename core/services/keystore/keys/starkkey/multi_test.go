@@ -0,0 +1,53 @@
+package starkkey
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiKey(t *testing.T) {
+	pubs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	t.Run("valid threshold", func(t *testing.T) {
+		multi, err := NewMultiKey(2, pubs)
+		require.NoError(t, err)
+		assert.Equal(t, 2, multi.Threshold)
+		assert.Equal(t, pubs, multi.PublicKeys)
+	})
+
+	t.Run("threshold too low", func(t *testing.T) {
+		_, err := NewMultiKey(0, pubs)
+		assert.Error(t, err)
+	})
+
+	t.Run("threshold above number of signers", func(t *testing.T) {
+		_, err := NewMultiKey(4, pubs)
+		assert.Error(t, err)
+	})
+}
+
+func TestMultiKey_ID(t *testing.T) {
+	pubs := []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+	multiA, err := NewMultiKey(2, pubs)
+	require.NoError(t, err)
+	multiB, err := NewMultiKey(2, pubs)
+	require.NoError(t, err)
+	assert.Equal(t, multiA.ID(), multiB.ID(), "ID must be deterministic for the same threshold and public keys")
+
+	multiDifferentThreshold, err := NewMultiKey(1, pubs)
+	require.NoError(t, err)
+	assert.NotEqual(t, multiA.ID(), multiDifferentThreshold.ID())
+
+	multiDifferentPubs, err := NewMultiKey(2, []*big.Int{big.NewInt(1), big.NewInt(3)})
+	require.NoError(t, err)
+	assert.NotEqual(t, multiA.ID(), multiDifferentPubs.ID())
+}
+
+func TestIDFromPublicKey(t *testing.T) {
+	pub := big.NewInt(42)
+	assert.Equal(t, Key{pub: pub}.ID(), IDFromPublicKey(pub))
+}
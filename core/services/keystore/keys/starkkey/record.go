@@ -0,0 +1,38 @@
+package starkkey
+
+import "math/big"
+
+// AccountKind identifies which account contract implementation a Record's AccountAddress was
+// derived against.
+type AccountKind int
+
+const (
+	AccountKindUnknown AccountKind = iota
+	AccountKindOpenZeppelin
+	AccountKindArgent
+	AccountKindBraavos
+)
+
+// RecordMetadata carries additional per-key data that doesn't belong on Key itself. It exists
+// so the keystore can grow new per-key metadata (today: the derived account address) without
+// another storage migration, mirroring the Cosmos SDK's move from Info to Record.
+type RecordMetadata struct {
+	// AccountAddress is the StarkNet account contract address derived from the key's public
+	// key, or nil if no StarkAccountDeriver has been configured to compute one.
+	AccountAddress *big.Int
+	// AccountKind identifies which account contract implementation AccountAddress was derived
+	// against. It's AccountKindUnknown whenever AccountAddress is nil.
+	AccountKind AccountKind
+}
+
+// Record is a Key plus its associated metadata. The keystore transparently migrates legacy
+// Key entries to Record the first time they're loaded, computing Metadata on the fly.
+type Record struct {
+	Key
+	Metadata RecordMetadata
+}
+
+// NewRecord wraps key with metadata.
+func NewRecord(key Key, metadata RecordMetadata) Record {
+	return Record{Key: key, Metadata: metadata}
+}
@@ -0,0 +1,31 @@
+// Package pb contains the wire types for the StarkSigner gRPC service defined in
+// starknet_signer.proto.
+//
+// NOTE: these are hand-written placeholders, not protoc-gen-go/protoc-gen-go-grpc output.
+// They satisfy the field access keystore.RemoteSignerClient and its callers need to compile
+// and to be tested against a fake, but they do not implement proto.Message or the generated
+// StarkSignerClient/StarkSignerServer interfaces, so they cannot actually be marshaled over
+// gRPC. Run `go generate ./...` (with protoc and the protoc-gen-go/protoc-gen-go-grpc plugins
+// installed) to replace this file with real generated code before wiring up a live remote
+// signer.
+package pb
+
+// SignRequest is the request message for StarkSigner.Sign.
+type SignRequest struct {
+	KeyId string
+	Hash  []byte
+}
+
+// SignResponse is the response message for StarkSigner.Sign.
+type SignResponse struct {
+	R []byte
+	S []byte
+}
+
+// ListAccountsRequest is the (empty) request message for StarkSigner.ListAccounts.
+type ListAccountsRequest struct{}
+
+// AccountList is the response message for StarkSigner.ListAccounts.
+type AccountList struct {
+	Accounts []string
+}
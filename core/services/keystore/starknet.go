@@ -5,57 +5,146 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/caigo"
 
 	"github.com/smartcontractkit/chainlink-relay/pkg/loop"
 	adapters "github.com/smartcontractkit/chainlink-relay/pkg/loop/adapters/starknet"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
 )
 
 //go:generate mockery --name StarkNet --output ./mocks/ --case=underscore --filename starknet.go
 type StarkNet interface {
-	Get(id string) (starkkey.Key, error)
-	GetAll() ([]starkkey.Key, error)
+	// Get and GetAll return the enriched Record for a key rather than the bare Key, so
+	// callers get the derived account address alongside the signing material. Legacy Key
+	// entries are transparently migrated to Record on read; nothing is rewritten to storage.
+	Get(id string) (starkkey.Record, error)
+	GetAll() ([]starkkey.Record, error)
+	// SetAccountDeriver overrides the StarkAccountDeriver used by default to compute the
+	// account address recorded in Record.Metadata for keys without their own deriver set via
+	// SetKeyAccountDeriver.
+	SetAccountDeriver(d StarkAccountDeriver)
+	// SetKeyAccountDeriver overrides the StarkAccountDeriver used for id's account address,
+	// independent of the keystore-wide default. Useful when a node's StarkNet keys are
+	// deployed behind different account contract implementations (Argent, Braavos, ...).
+	SetKeyAccountDeriver(id string, d StarkAccountDeriver) error
 	Create() (starkkey.Key, error)
 	Add(key starkkey.Key) error
+	// AddLedger registers a Ledger-backed key: only the public key and derivation path are
+	// stored, never a private scalar.
+	AddLedger(derivationPath string, publicKey *big.Int) (starkkey.Key, error)
 	Delete(id string) (starkkey.Key, error)
 	Import(keyJSON []byte, password string) (starkkey.Key, error)
 	Export(id string, password string) ([]byte, error)
 	EnsureKey() error
+	// Watch begins watching dir for scrypt-encrypted StarkNet keyfiles, adding/removing keys
+	// as files are created, updated, or deleted, without requiring a process restart.
+	Watch(ctx context.Context, dir string, passphrase PassphraseFunc) error
+	// CreateMulti composes pubs into a threshold-of-N StarkNet multisig key, registered under
+	// its deterministic ID.
+	CreateMulti(threshold int, pubs []*big.Int) (starkkey.MultiKey, error)
+	// GetMulti looks up a previously created multisig key by ID.
+	GetMulti(id string) (starkkey.MultiKey, error)
+	// DeleteMulti removes a previously created multisig composite by ID. It does not accept a
+	// single-key ID; use Delete for that.
+	DeleteMulti(id string) error
 }
 
+// starknet is the default StarkNet keystore implementation: keys live in the node's
+// Postgres-backed keyRing. See NewStarkNet in starknet_backend.go for an alternative
+// implementation backed by an OS keychain, `pass`, an encrypted file directory, or memory.
 type starknet struct {
 	*keyManager
+	deriver StarkAccountDeriver
+	// keyDerivers holds per-key overrides of deriver, set via SetKeyAccountDeriver, for nodes
+	// whose StarkNet keys aren't all deployed behind the same account contract implementation.
+	keyDerivers map[string]StarkAccountDeriver
+	// multi holds composed multisig keys. Unlike keyRing.StarkNet, it is not persisted: a
+	// MultiKey carries no private material, so it's fully reconstructible by calling
+	// CreateMulti again with the same threshold and public keys (ID is deterministic).
+	multi map[string]starkkey.MultiKey
 }
 
 var _ StarkNet = &starknet{}
 
 func newStarkNetKeyStore(km *keyManager) *starknet {
 	return &starknet{
-		km,
+		keyManager: km,
+		deriver:    defaultAccountDeriver,
 	}
 }
 
-func (ks *starknet) Get(id string) (starkkey.Key, error) {
+// SetAccountDeriver overrides the StarkAccountDeriver used to compute the account address
+// recorded in Record.Metadata for keys loaded after this call.
+func (ks *starknet) SetAccountDeriver(d StarkAccountDeriver) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.deriver = d
+}
+
+// SetKeyAccountDeriver overrides the StarkAccountDeriver used for id's account address,
+// independent of the keystore-wide default set via SetAccountDeriver.
+func (ks *starknet) SetKeyAccountDeriver(id string, d StarkAccountDeriver) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if _, found := ks.keyRing.StarkNet[id]; !found {
+		return KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	if ks.keyDerivers == nil {
+		ks.keyDerivers = make(map[string]StarkAccountDeriver)
+	}
+	ks.keyDerivers[id] = d
+	return nil
+}
+
+func (ks *starknet) Get(id string) (starkkey.Record, error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
 	if ks.isLocked() {
-		return starkkey.Key{}, ErrLocked
+		return starkkey.Record{}, ErrLocked
+	}
+	key, err := ks.getByID(id)
+	if err != nil {
+		return starkkey.Record{}, err
 	}
-	return ks.getByID(id)
+	return ks.toRecord(key), nil
 }
 
-func (ks *starknet) GetAll() (keys []starkkey.Key, _ error) {
+func (ks *starknet) GetAll() (records []starkkey.Record, _ error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
 	if ks.isLocked() {
 		return nil, ErrLocked
 	}
 	for _, key := range ks.keyRing.StarkNet {
-		keys = append(keys, key)
+		records = append(records, ks.toRecord(key))
 	}
-	return keys, nil
+	return records, nil
+}
+
+// toRecord wraps key in a Record, deriving its account address via the key's StarkAccountDeriver
+// (its own one set through SetKeyAccountDeriver, falling back to ks.deriver). Ledger-backed
+// keys carry a public key the same as software keys, so they're derived the same way. This is
+// where legacy Key entries get transparently migrated to Record: nothing is rewritten to
+// storage, every read just wraps the stored Key with freshly computed metadata.
+func (ks *starknet) toRecord(key starkkey.Key) starkkey.Record {
+	var metadata starkkey.RecordMetadata
+	deriver := ks.deriver
+	if d, found := ks.keyDerivers[key.ID()]; found {
+		deriver = d
+	}
+	if deriver != nil {
+		addr, err := deriver.DeriveAccount(key.PublicKey())
+		if err != nil {
+			ks.logger.Errorf("starknet keystore: failed to derive account address for key %s: %v", key.ID(), err)
+		} else {
+			metadata.AccountAddress = addr
+			metadata.AccountKind = deriver.Kind()
+		}
+	}
+	return starkkey.NewRecord(key, metadata)
 }
 
 func (ks *starknet) Create() (starkkey.Key, error) {
@@ -83,6 +172,26 @@ func (ks *starknet) Add(key starkkey.Key) error {
 	return ks.safeAddKey(key)
 }
 
+// AddLedger registers a Ledger hardware wallet key: only the stark-curve public key and the
+// derivation path used to obtain it are stored. The returned Key's ToPrivKey is nil; Sign
+// requests for its ID must go through a signer with a LedgerHub: a StarknetLedgerSigner, or a
+// StarknetLooppSigner built with NewStarkNetLooppKeystoreWithLedger.
+func (ks *starknet) AddLedger(derivationPath string, publicKey *big.Int) (starkkey.Key, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return starkkey.Key{}, ErrLocked
+	}
+	key, err := starkkey.NewLedger(derivationPath, publicKey)
+	if err != nil {
+		return starkkey.Key{}, err
+	}
+	if _, found := ks.keyRing.StarkNet[key.ID()]; found {
+		return starkkey.Key{}, fmt.Errorf("key with ID %s already exists", key.ID())
+	}
+	return key, ks.safeAddKey(key)
+}
+
 func (ks *starknet) Delete(id string) (starkkey.Key, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
@@ -123,6 +232,9 @@ func (ks *starknet) Export(id string, password string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if key.IsLedger() {
+		return nil, fmt.Errorf("cannot export ledger-backed key %s", id)
+	}
 	return starkkey.ToEncryptedJSON(key, password, ks.scryptParams)
 }
 
@@ -146,6 +258,55 @@ func (ks *starknet) EnsureKey() error {
 	return ks.safeAddKey(key)
 }
 
+// Watch starts a starkkeyFileCache over dir. It returns once the initial directory scan has
+// completed; subsequent additions, updates, and removals are applied asynchronously until ctx
+// is canceled.
+func (ks *starknet) Watch(ctx context.Context, dir string, passphrase PassphraseFunc) error {
+	cache := newStarkkeyFileCache(ks, dir, passphrase)
+	return cache.start(ctx)
+}
+
+// CreateMulti composes pubs into a threshold-of-N StarkNet multisig key and registers it
+// under its deterministic ID.
+func (ks *starknet) CreateMulti(threshold int, pubs []*big.Int) (starkkey.MultiKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return starkkey.MultiKey{}, ErrLocked
+	}
+	multi, err := starkkey.NewMultiKey(threshold, pubs)
+	if err != nil {
+		return starkkey.MultiKey{}, err
+	}
+	if ks.multi == nil {
+		ks.multi = make(map[string]starkkey.MultiKey)
+	}
+	ks.multi[multi.ID()] = multi
+	return multi, nil
+}
+
+// GetMulti looks up a previously created multisig key by ID.
+func (ks *starknet) GetMulti(id string) (starkkey.MultiKey, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	multi, found := ks.multi[id]
+	if !found {
+		return starkkey.MultiKey{}, KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	return multi, nil
+}
+
+// DeleteMulti removes a previously created multisig composite by ID.
+func (ks *starknet) DeleteMulti(id string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if _, found := ks.multi[id]; !found {
+		return KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	delete(ks.multi, id)
+	return nil
+}
+
 func (ks *starknet) getByID(id string) (starkkey.Key, error) {
 	key, found := ks.keyRing.StarkNet[id]
 	if !found {
@@ -204,18 +365,41 @@ func (ca *keystoreAdapter) Loopp() loop.Keystore {
 // of signature d/encoding of the [KeystoreAdapter]
 type StarknetLooppSigner struct {
 	StarkNet
+	// ledgerHub is nil unless the node has Ledger-backed StarkNet keys configured; Sign only
+	// consults it for keys whose ToPrivKey is nil.
+	ledgerHub LedgerHub
+	// lggr is nil unless SetLogger has been called; Accounts guards every call through it so
+	// logging stays optional.
+	lggr logger.Logger
 }
 
 func NewStarkNetLooppKeystore(ks StarkNet) *StarknetLooppSigner {
 	return &StarknetLooppSigner{StarkNet: ks}
 }
 
+// SetLogger attaches lggr so Accounts can report keys it has to skip instead of silently
+// dropping them.
+func (lk *StarknetLooppSigner) SetLogger(lggr logger.Logger) {
+	lk.lggr = lggr.Named("StarknetLooppSigner")
+}
+
+// NewStarkNetLooppKeystoreWithLedger is like NewStarkNetLooppKeystore but additionally wires
+// up hub so that Sign can service Ledger-backed keys.
+func NewStarkNetLooppKeystoreWithLedger(ks StarkNet, hub LedgerHub) *StarknetLooppSigner {
+	return &StarknetLooppSigner{StarkNet: ks, ledgerHub: hub}
+}
+
 var _ loop.Keystore = &StarknetLooppSigner{}
 
 // Sign implements [loop.Keystore]
 // hash is expected to be the byte representation of big.Int
 // the return []byte is encodes a starknet signature per [signature.bytes]
 func (lk *StarknetLooppSigner) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	// id may name a multisig composite rather than a single key; check that first since a
+	// multi ID is never found in the single-key keyring.
+	if multi, err := lk.GetMulti(id); err == nil {
+		return lk.signMulti(multi, hash)
+	}
 
 	k, err := lk.Get(id)
 	if err != nil {
@@ -227,7 +411,16 @@ func (lk *StarknetLooppSigner) Sign(ctx context.Context, id string, hash []byte)
 	}
 
 	starkHash := new(big.Int).SetBytes(hash)
-	x, y, err := caigo.Curve.Sign(starkHash, k.ToPrivKey())
+
+	var x, y *big.Int
+	if k.IsLedger() {
+		if lk.ledgerHub == nil {
+			return nil, fmt.Errorf("no LedgerHub configured to sign for ledger-backed key %s", id)
+		}
+		x, y, err = signWithLedger(lk.ledgerHub, k.Key, starkHash)
+	} else {
+		x, y, err = caigo.Curve.Sign(starkHash, k.ToPrivKey())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error signing data with curve: %w", err)
 	}
@@ -239,7 +432,23 @@ func (lk *StarknetLooppSigner) Sign(ctx context.Context, id string, hash []byte)
 	return sig.Bytes()
 }
 
-// TODO what is this supposed to return for starknet?
+// Accounts implements [loop.Keystore]. It returns the StarkNet account contract addresses
+// this node can sign for, derived from each key's public key via its configured
+// StarkAccountDeriver, rather than the bare key IDs.
 func (lk *StarknetLooppSigner) Accounts(ctx context.Context) ([]string, error) {
-	return nil, fmt.Errorf("unimplemented")
+	records, err := lk.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]string, 0, len(records))
+	for _, r := range records {
+		if r.Metadata.AccountAddress == nil {
+			if lk.lggr != nil {
+				lk.lggr.Warnf("starknet keystore: omitting key %s from Accounts: no account address could be derived for it", r.ID())
+			}
+			continue
+		}
+		accounts = append(accounts, hexutil.EncodeBig(r.Metadata.AccountAddress))
+	}
+	return accounts, nil
 }
@@ -0,0 +1,142 @@
+package keystore
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/caigo"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+)
+
+// StarkAccountDeriver computes the StarkNet account contract address that a given account
+// contract implementation (Argent, OpenZeppelin, Braavos, ...) would deploy for a signer's
+// public key, using the standard StarkNet calculateContractAddressFromHash formula:
+//
+//	address = pedersen("STARKNET_CONTRACT_ADDRESS", deployer, salt, classHash, hash(calldata))
+//
+// Implementations differ only in which proxy/account class hash and constructor calldata
+// layout they plug into that formula.
+type StarkAccountDeriver interface {
+	DeriveAccount(pubKey *big.Int) (*big.Int, error)
+	// Kind identifies which account contract implementation this deriver computes addresses
+	// for, so Record.Metadata can record it without re-deriving or guessing from the deriver
+	// value.
+	Kind() starkkey.AccountKind
+}
+
+// contractAddressPrefix is the constant StarkNet itself hashes in as the first element of
+// calculateContractAddressFromHash.
+var contractAddressPrefix = bytesToFelt([]byte("STARKNET_CONTRACT_ADDRESS"))
+
+func bytesToFelt(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+// addrBound is StarkNet's ADDR_BOUND = 2**251 - 256, the upper bound every contract address is
+// masked into. calculateContractAddressFromHash's raw pedersen chain can exceed this; without
+// reducing mod addrBound, the derived address wouldn't match the one StarkNet itself assigns
+// at deployment.
+var addrBound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(256))
+
+// calculateContractAddressFromHash implements StarkNet's standard counterfactual account
+// address derivation: deployer is 0 for addresses computed against the universal deployer
+// convention used by account contracts.
+func calculateContractAddressFromHash(salt, classHash *big.Int, constructorCalldata []*big.Int, deployer *big.Int) (*big.Int, error) {
+	calldataHash, err := caigo.Curve.ComputeHashOnElements(constructorCalldata)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing constructor calldata: %w", err)
+	}
+	addr, err := caigo.Curve.ComputeHashOnElements([]*big.Int{
+		contractAddressPrefix,
+		deployer,
+		salt,
+		classHash,
+		calldataHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mod(addr, addrBound), nil
+}
+
+// argentAccountDeriver derives the account address Argent X deploys for a given signer
+// public key: the proxy class hash with the account implementation hash, the public key, and
+// a nil guardian in the constructor calldata.
+type argentAccountDeriver struct {
+	proxyClassHash          *big.Int
+	implementationClassHash *big.Int
+}
+
+// NewArgentAccountDeriver returns a StarkAccountDeriver for Argent X's current proxy and
+// account implementation class hashes.
+func NewArgentAccountDeriver(proxyClassHash, implementationClassHash *big.Int) StarkAccountDeriver {
+	return argentAccountDeriver{proxyClassHash: proxyClassHash, implementationClassHash: implementationClassHash}
+}
+
+func (d argentAccountDeriver) DeriveAccount(pubKey *big.Int) (*big.Int, error) {
+	calldata := []*big.Int{d.implementationClassHash, pubKey, big.NewInt(0) /* guardian */, big.NewInt(0)}
+	return calculateContractAddressFromHash(pubKey, d.proxyClassHash, calldata, big.NewInt(0))
+}
+
+func (d argentAccountDeriver) Kind() starkkey.AccountKind { return starkkey.AccountKindArgent }
+
+// openZeppelinAccountDeriver derives the account address the OpenZeppelin reference account
+// contract deploys for a given signer public key.
+type openZeppelinAccountDeriver struct {
+	classHash *big.Int
+}
+
+// NewOpenZeppelinAccountDeriver returns a StarkAccountDeriver for the OpenZeppelin reference
+// account contract's class hash.
+func NewOpenZeppelinAccountDeriver(classHash *big.Int) StarkAccountDeriver {
+	return openZeppelinAccountDeriver{classHash: classHash}
+}
+
+func (d openZeppelinAccountDeriver) DeriveAccount(pubKey *big.Int) (*big.Int, error) {
+	calldata := []*big.Int{pubKey}
+	return calculateContractAddressFromHash(pubKey, d.classHash, calldata, big.NewInt(0))
+}
+
+func (d openZeppelinAccountDeriver) Kind() starkkey.AccountKind {
+	return starkkey.AccountKindOpenZeppelin
+}
+
+// braavosAccountDeriver derives the account address Braavos deploys for a given signer public
+// key: the proxy class hash with the account implementation hash and the public key in the
+// constructor calldata.
+type braavosAccountDeriver struct {
+	proxyClassHash          *big.Int
+	implementationClassHash *big.Int
+}
+
+// NewBraavosAccountDeriver returns a StarkAccountDeriver for Braavos's current proxy and
+// account implementation class hashes.
+func NewBraavosAccountDeriver(proxyClassHash, implementationClassHash *big.Int) StarkAccountDeriver {
+	return braavosAccountDeriver{proxyClassHash: proxyClassHash, implementationClassHash: implementationClassHash}
+}
+
+func (d braavosAccountDeriver) DeriveAccount(pubKey *big.Int) (*big.Int, error) {
+	calldata := []*big.Int{d.implementationClassHash, pubKey}
+	return calculateContractAddressFromHash(pubKey, d.proxyClassHash, calldata, big.NewInt(0))
+}
+
+func (d braavosAccountDeriver) Kind() starkkey.AccountKind { return starkkey.AccountKindBraavos }
+
+// openZeppelinClassHash is the OpenZeppelin reference account contract's class hash as
+// currently deployed by the chainlink-starknet relayer's bootstrap tooling. Update this
+// alongside that tooling when the deployed account version changes.
+var openZeppelinClassHash = mustFelt("0x036078334509b514626504edc9fb252328d1a240e4e948bef8d0c08dff45927")
+
+// defaultAccountDeriver is used by newStarkNetKeyStore and NewStarkNet unless overridden via
+// SetAccountDeriver. OpenZeppelin's reference account is the default most StarkNet tooling
+// deploys against.
+var defaultAccountDeriver StarkAccountDeriver = NewOpenZeppelinAccountDeriver(openZeppelinClassHash)
+
+func mustFelt(hex string) *big.Int {
+	felt, ok := new(big.Int).SetString(hex[2:], 16)
+	if !ok {
+		panic("keystore: invalid felt literal " + hex)
+	}
+	return felt
+}
@@ -0,0 +1,54 @@
+package keystore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+)
+
+func TestAccountDerivers_Deterministic(t *testing.T) {
+	pub := big.NewInt(555)
+	derivers := []StarkAccountDeriver{
+		defaultAccountDeriver,
+		NewArgentAccountDeriver(big.NewInt(1), big.NewInt(2)),
+		NewBraavosAccountDeriver(big.NewInt(3), big.NewInt(4)),
+	}
+	for _, d := range derivers {
+		addrA, err := d.DeriveAccount(pub)
+		require.NoError(t, err)
+		addrB, err := d.DeriveAccount(pub)
+		require.NoError(t, err)
+		assert.Equal(t, addrA, addrB, "deriving the same public key twice must produce the same address")
+	}
+}
+
+func TestAccountDerivers_DifferByPublicKey(t *testing.T) {
+	d := defaultAccountDeriver
+	addrA, err := d.DeriveAccount(big.NewInt(1))
+	require.NoError(t, err)
+	addrB, err := d.DeriveAccount(big.NewInt(2))
+	require.NoError(t, err)
+	assert.NotEqual(t, addrA, addrB)
+}
+
+func TestAccountDerivers_Kind(t *testing.T) {
+	assert.Equal(t, starkkey.AccountKindOpenZeppelin, defaultAccountDeriver.Kind())
+	assert.Equal(t, starkkey.AccountKindArgent, NewArgentAccountDeriver(big.NewInt(1), big.NewInt(2)).Kind())
+	assert.Equal(t, starkkey.AccountKindBraavos, NewBraavosAccountDeriver(big.NewInt(1), big.NewInt(2)).Kind())
+}
+
+func TestAccountDerivers_DifferByAccountKind(t *testing.T) {
+	pub := big.NewInt(555)
+	oz := NewOpenZeppelinAccountDeriver(big.NewInt(1))
+	argent := NewArgentAccountDeriver(big.NewInt(1), big.NewInt(2))
+
+	ozAddr, err := oz.DeriveAccount(pub)
+	require.NoError(t, err)
+	argentAddr, err := argent.DeriveAccount(pub)
+	require.NoError(t, err)
+	assert.NotEqual(t, ozAddr, argentAddr, "different account contract implementations must deploy at different addresses")
+}
@@ -0,0 +1,586 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/99designs/keyring"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// Backend is the storage abstraction behind the StarkNet keystore, modeled on the
+// Cosmos SDK Keyring interface. A backend only ever sees the encoded blob for a key, keyed by
+// the key's ID: scrypt-encrypted JSON for a software key, plain JSON for a Ledger-backed key
+// or a multisig composite, each behind a leading tag byte. Encoding/decoding around that
+// boundary is the keystore's job, not the backend's.
+type Backend interface {
+	Get(id string) ([]byte, error)
+	List() ([]string, error)
+	Put(id string, encryptedJSON []byte) error
+	Delete(id string) error
+	Has(id string) bool
+}
+
+// backendPassphrase is the scrypt passphrase dbBackend uses to encrypt key material inside the
+// node's existing Postgres-backed keyRing. It is intentionally not operator-configurable there:
+// the real protection boundary for that backend is Postgres access control, and the scrypt
+// envelope exists only so the stored bytes are format-compatible with Import/Export, which use
+// the caller's password. backendStarknet, by contrast, stores keys behind an external backend
+// (OS keychain, pass, an encrypted file) where the envelope passphrase is part of the real
+// protection boundary, so it takes its own operator-supplied passphrase instead of this constant.
+const backendPassphrase = ""
+
+// keyEncodingSoftware, keyEncodingLedger, and keyEncodingMulti tag the first byte of the blob
+// a Backend stores under an ID, so readers know which format follows without guessing.
+// keyEncodingMulti records are composite MultiKey JSON, not single keys; GetAll skips them,
+// and decodeBackendKey rejects them as an unexpected encoding.
+const (
+	keyEncodingSoftware byte = 0
+	keyEncodingLedger   byte = 1
+	keyEncodingMulti    byte = 2
+)
+
+// encodeBackendKey serializes key for storage behind a Backend. A software key is
+// scrypt-encrypted with passphrase, same as Export; a Ledger-backed key has no private scalar
+// to encrypt, so it's marshaled as plain starkkey.LedgerKeyData JSON instead. The leading tag
+// byte records which format follows so decodeBackendKey can reverse it without guessing.
+func encodeBackendKey(key starkkey.Key, passphrase string, scryptParams utils.ScryptParams) ([]byte, error) {
+	if key.IsLedger() {
+		data, err := json.Marshal(key.ToLedgerData())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode ledger key for storage")
+		}
+		return append([]byte{keyEncodingLedger}, data...), nil
+	}
+	encrypted, err := starkkey.ToEncryptedJSON(key, passphrase, scryptParams)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{keyEncodingSoftware}, encrypted...), nil
+}
+
+// decodeBackendKey reverses encodeBackendKey.
+func decodeBackendKey(raw []byte, passphrase string) (starkkey.Key, error) {
+	if len(raw) == 0 {
+		return starkkey.Key{}, fmt.Errorf("starknet keystore: empty key record")
+	}
+	switch raw[0] {
+	case keyEncodingSoftware:
+		return starkkey.FromEncryptedJSON(raw[1:], passphrase)
+	case keyEncodingLedger:
+		var data starkkey.LedgerKeyData
+		if err := json.Unmarshal(raw[1:], &data); err != nil {
+			return starkkey.Key{}, errors.Wrap(err, "failed to decode ledger key record")
+		}
+		return starkkey.LedgerFromData(data)
+	default:
+		return starkkey.Key{}, fmt.Errorf("starknet keystore: unexpected key record encoding %d", raw[0])
+	}
+}
+
+// dbBackend is the default Backend: it keeps StarkNet keys in the node's existing
+// Postgres-backed keyRing, so nodes that don't opt into an external backend see no change in
+// behavior.
+type dbBackend struct {
+	km *keyManager
+}
+
+func newDBBackend(km *keyManager) *dbBackend {
+	return &dbBackend{km: km}
+}
+
+func (b *dbBackend) Get(id string) ([]byte, error) {
+	b.km.lock.RLock()
+	defer b.km.lock.RUnlock()
+	key, found := b.km.keyRing.StarkNet[id]
+	if !found {
+		return nil, KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	return encodeBackendKey(key, backendPassphrase, b.km.scryptParams)
+}
+
+func (b *dbBackend) List() ([]string, error) {
+	b.km.lock.RLock()
+	defer b.km.lock.RUnlock()
+	ids := make([]string, 0, len(b.km.keyRing.StarkNet))
+	for id := range b.km.keyRing.StarkNet {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (b *dbBackend) Put(_ string, encoded []byte) error {
+	key, err := decodeBackendKey(encoded, backendPassphrase)
+	if err != nil {
+		return errors.Wrap(err, "dbBackend#Put failed to decode key")
+	}
+	b.km.lock.Lock()
+	defer b.km.lock.Unlock()
+	return b.km.safeAddKey(key)
+}
+
+func (b *dbBackend) Delete(id string) error {
+	b.km.lock.Lock()
+	defer b.km.lock.Unlock()
+	key, found := b.km.keyRing.StarkNet[id]
+	if !found {
+		return KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	return b.km.safeRemoveKey(key)
+}
+
+func (b *dbBackend) Has(id string) bool {
+	b.km.lock.RLock()
+	defer b.km.lock.RUnlock()
+	_, found := b.km.keyRing.StarkNet[id]
+	return found
+}
+
+// memoryBackend is an in-memory Backend. It is not persisted anywhere and exists for
+// tests and for ephemeral StarkNet keys that should never touch disk or Postgres.
+type memoryBackend struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{data: map[string][]byte{}}
+}
+
+func (b *memoryBackend) Get(id string) ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	v, found := b.data[id]
+	if !found {
+		return nil, KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	return v, nil
+}
+
+func (b *memoryBackend) List() ([]string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ids := make([]string, 0, len(b.data))
+	for id := range b.data {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (b *memoryBackend) Put(id string, encryptedJSON []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data[id] = encryptedJSON
+	return nil
+}
+
+func (b *memoryBackend) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.data, id)
+	return nil
+}
+
+func (b *memoryBackend) Has(id string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, found := b.data[id]
+	return found
+}
+
+// keyringBackend wraps github.com/99designs/keyring, which provides a uniform API over the
+// macOS Keychain, Windows Credential Manager, libsecret (Linux), KWallet, the `pass`
+// password store, and a plain encrypted-file directory. Which of those is used is decided by
+// the BackendType passed to newKeyringBackend.
+type keyringBackend struct {
+	kr keyring.Keyring
+}
+
+// newKeyringBackend opens (creating if necessary) a 99designs/keyring store of the given
+// backend type. serviceName namespaces the keys so that multiple Chainlink nodes can share a
+// single OS keychain without colliding; fileDir is only consulted by keyring.FileBackend.
+// filePassphrase unlocks keyring.FileBackend and keyring.PassBackend; it is ignored by backends
+// that have their own OS-level authentication (the Keychain, Credential Manager, libsecret).
+func newKeyringBackend(serviceName string, backendType keyring.BackendType, fileDir, filePassphrase string) (*keyringBackend, error) {
+	kr, err := keyring.Open(keyring.Config{
+		ServiceName:      serviceName,
+		AllowedBackends:  []keyring.BackendType{backendType},
+		FileDir:          fileDir,
+		FilePasswordFunc: keyring.FixedStringPrompt(filePassphrase),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open starknet keyring backend")
+	}
+	return &keyringBackend{kr: kr}, nil
+}
+
+func (b *keyringBackend) Get(id string) ([]byte, error) {
+	item, err := b.kr.Get(id)
+	if err != nil {
+		if errors.Is(err, keyring.ErrKeyNotFound) {
+			return nil, KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+		}
+		return nil, err
+	}
+	return item.Data, nil
+}
+
+func (b *keyringBackend) List() ([]string, error) {
+	return b.kr.Keys()
+}
+
+func (b *keyringBackend) Put(id string, encryptedJSON []byte) error {
+	return b.kr.Set(keyring.Item{Key: id, Data: encryptedJSON})
+}
+
+func (b *keyringBackend) Delete(id string) error {
+	return b.kr.Remove(id)
+}
+
+func (b *keyringBackend) Has(id string) bool {
+	_, err := b.kr.Get(id)
+	return err == nil
+}
+
+// NewOSKeychainBackend stores StarkNet keys in the platform-native credential store: macOS
+// Keychain, Windows Credential Manager, or libsecret on Linux, selected by the node's GOOS.
+func NewOSKeychainBackend(serviceName string) (Backend, error) {
+	var backendType keyring.BackendType
+	switch runtime.GOOS {
+	case "darwin":
+		backendType = keyring.KeychainBackend
+	case "windows":
+		backendType = keyring.WinCredBackend
+	default:
+		backendType = keyring.SecretServiceBackend // libsecret, e.g. Linux
+	}
+	return newKeyringBackend(serviceName, backendType, "", "")
+}
+
+// NewPassBackend stores StarkNet keys in the `pass` standard unix password manager. passphrase
+// unlocks the GPG-encrypted pass store; it is the operator's responsibility to keep it as
+// secret as the StarkNet keys it protects.
+func NewPassBackend(serviceName, passphrase string) (Backend, error) {
+	return newKeyringBackend(serviceName, keyring.PassBackend, "", passphrase)
+}
+
+// NewFileBackend stores StarkNet keys as individual scrypt-encrypted files under dir, encrypted
+// at rest with passphrase. Unlike the OS keychain and pass backends, a file backend has no
+// platform-level authentication of its own, so passphrase is the only thing standing between
+// dir and the StarkNet private scalars it contains.
+func NewFileBackend(serviceName, dir, passphrase string) (Backend, error) {
+	return newKeyringBackend(serviceName, keyring.FileBackend, dir, passphrase)
+}
+
+// NewMemoryBackend stores StarkNet keys in memory only. Intended for tests.
+func NewMemoryBackend() Backend {
+	return newMemoryBackend()
+}
+
+// backendStarknet is a StarkNet keystore whose key material is stored behind a pluggable
+// Backend instead of the node's Postgres-backed keyRing, so operators can keep
+// StarkNet private scalars out of the database entirely.
+type backendStarknet struct {
+	lock         sync.RWMutex
+	backend      Backend
+	passphrase   string
+	scryptParams utils.ScryptParams
+	lggr         logger.Logger
+	deriver      StarkAccountDeriver
+	// keyDerivers holds per-key overrides of deriver, set via SetKeyAccountDeriver; see the
+	// equivalent field on starknet.
+	keyDerivers map[string]StarkAccountDeriver
+	// multi caches composed multisig keys. Unlike the equivalent field on starknet, these are
+	// also persisted to backend by CreateMulti, and GetMulti reloads them from there on a miss.
+	multi map[string]starkkey.MultiKey
+}
+
+var _ StarkNet = &backendStarknet{}
+
+// NewStarkNet constructs a StarkNet keystore backed by backend, alongside the default
+// newStarkNetKeyStore constructor which remains Postgres-backed. passphrase is the scrypt
+// passphrase used to envelope key material at rest inside backend; unlike dbBackend's fixed
+// backendPassphrase, this is operator-supplied because the backend itself (an encrypted file,
+// `pass`) may not provide its own protection boundary. Import/Export stay scrypt-JSON format
+// compatible regardless of which backend is configured.
+func NewStarkNet(backend Backend, passphrase string, scryptParams utils.ScryptParams, lggr logger.Logger) StarkNet {
+	return &backendStarknet{
+		backend:      backend,
+		passphrase:   passphrase,
+		scryptParams: scryptParams,
+		lggr:         lggr.Named("StarkNetKeyStore"),
+		deriver:      defaultAccountDeriver,
+	}
+}
+
+// SetAccountDeriver overrides the StarkAccountDeriver used to compute the account address
+// recorded in Record.Metadata for keys loaded after this call.
+func (ks *backendStarknet) SetAccountDeriver(d StarkAccountDeriver) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.deriver = d
+}
+
+// SetKeyAccountDeriver overrides the StarkAccountDeriver used for id's account address,
+// independent of the keystore-wide default set via SetAccountDeriver.
+func (ks *backendStarknet) SetKeyAccountDeriver(id string, d StarkAccountDeriver) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if !ks.backend.Has(id) {
+		return KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	if ks.keyDerivers == nil {
+		ks.keyDerivers = make(map[string]StarkAccountDeriver)
+	}
+	ks.keyDerivers[id] = d
+	return nil
+}
+
+// CreateMulti composes pubs into a threshold-of-N StarkNet multisig key, persists it to
+// backend under its deterministic ID (tagged so GetAll doesn't mistake it for a single-key
+// record), and registers it in ks.multi for fast lookup.
+func (ks *backendStarknet) CreateMulti(threshold int, pubs []*big.Int) (starkkey.MultiKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	multi, err := starkkey.NewMultiKey(threshold, pubs)
+	if err != nil {
+		return starkkey.MultiKey{}, err
+	}
+	if err := ks.putMulti(multi); err != nil {
+		return starkkey.MultiKey{}, err
+	}
+	if ks.multi == nil {
+		ks.multi = make(map[string]starkkey.MultiKey)
+	}
+	ks.multi[multi.ID()] = multi
+	return multi, nil
+}
+
+func (ks *backendStarknet) putMulti(multi starkkey.MultiKey) error {
+	data, err := json.Marshal(multi)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode multisig key for storage")
+	}
+	return ks.backend.Put(multi.ID(), append([]byte{keyEncodingMulti}, data...))
+}
+
+// GetMulti looks up a previously created multisig key by ID, first in the in-memory cache and
+// then, on a cache miss (e.g. after a process restart), in backend.
+func (ks *backendStarknet) GetMulti(id string) (starkkey.MultiKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if multi, found := ks.multi[id]; found {
+		return multi, nil
+	}
+	raw, err := ks.backend.Get(id)
+	if err != nil || len(raw) == 0 || raw[0] != keyEncodingMulti {
+		return starkkey.MultiKey{}, KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	var multi starkkey.MultiKey
+	if err := json.Unmarshal(raw[1:], &multi); err != nil {
+		return starkkey.MultiKey{}, errors.Wrap(err, "failed to decode multisig key record")
+	}
+	if ks.multi == nil {
+		ks.multi = make(map[string]starkkey.MultiKey)
+	}
+	ks.multi[id] = multi
+	return multi, nil
+}
+
+// DeleteMulti removes a previously created multisig composite by ID, both from backend and
+// from the in-memory cache.
+func (ks *backendStarknet) DeleteMulti(id string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	raw, err := ks.backend.Get(id)
+	if err != nil || len(raw) == 0 || raw[0] != keyEncodingMulti {
+		return KeyNotFoundError{ID: id, KeyType: "StarkNet"}
+	}
+	if err := ks.backend.Delete(id); err != nil {
+		return err
+	}
+	delete(ks.multi, id)
+	return nil
+}
+
+func (ks *backendStarknet) Get(id string) (starkkey.Record, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	key, err := ks.getByID(id)
+	if err != nil {
+		return starkkey.Record{}, err
+	}
+	return ks.toRecord(key), nil
+}
+
+func (ks *backendStarknet) GetAll() ([]starkkey.Record, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	ids, err := ks.backend.List()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]starkkey.Record, 0, len(ids))
+	for _, id := range ids {
+		raw, err := ks.backend.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) > 0 && raw[0] == keyEncodingMulti {
+			continue // composite multisig keys are looked up via GetMulti, not GetAll
+		}
+		key, err := decodeBackendKey(raw, ks.passphrase)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, ks.toRecord(key))
+	}
+	return records, nil
+}
+
+// toRecord wraps key in a Record, deriving its account address via the key's StarkAccountDeriver
+// (its own one set through SetKeyAccountDeriver, falling back to ks.deriver). Ledger-backed
+// keys carry a public key the same as software keys, so they're derived the same way. Legacy
+// Key entries are transparently migrated to Record this way: nothing is rewritten to the
+// backend, every read just wraps the stored Key with freshly computed metadata.
+func (ks *backendStarknet) toRecord(key starkkey.Key) starkkey.Record {
+	var metadata starkkey.RecordMetadata
+	deriver := ks.deriver
+	if d, found := ks.keyDerivers[key.ID()]; found {
+		deriver = d
+	}
+	if deriver != nil {
+		addr, err := deriver.DeriveAccount(key.PublicKey())
+		if err != nil {
+			ks.lggr.Errorf("starknet keystore: failed to derive account address for key %s: %v", key.ID(), err)
+		} else {
+			metadata.AccountAddress = addr
+			metadata.AccountKind = deriver.Kind()
+		}
+	}
+	return starkkey.NewRecord(key, metadata)
+}
+
+func (ks *backendStarknet) Create() (starkkey.Key, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := starkkey.New()
+	if err != nil {
+		return starkkey.Key{}, err
+	}
+	return key, ks.putKey(key)
+}
+
+func (ks *backendStarknet) Add(key starkkey.Key) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.backend.Has(key.ID()) {
+		return fmt.Errorf("key with ID %s already exists", key.ID())
+	}
+	return ks.putKey(key)
+}
+
+func (ks *backendStarknet) AddLedger(derivationPath string, publicKey *big.Int) (starkkey.Key, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := starkkey.NewLedger(derivationPath, publicKey)
+	if err != nil {
+		return starkkey.Key{}, err
+	}
+	if ks.backend.Has(key.ID()) {
+		return starkkey.Key{}, fmt.Errorf("key with ID %s already exists", key.ID())
+	}
+	return key, ks.putKey(key)
+}
+
+func (ks *backendStarknet) Delete(id string) (starkkey.Key, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := ks.getByID(id)
+	if err != nil {
+		return starkkey.Key{}, err
+	}
+	if err := ks.backend.Delete(id); err != nil {
+		return starkkey.Key{}, err
+	}
+	return key, nil
+}
+
+func (ks *backendStarknet) Import(keyJSON []byte, password string) (starkkey.Key, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	key, err := starkkey.FromEncryptedJSON(keyJSON, password)
+	if err != nil {
+		return starkkey.Key{}, errors.Wrap(err, "StarkNetKeyStore#Import failed to decrypt key")
+	}
+	if ks.backend.Has(key.ID()) {
+		return starkkey.Key{}, fmt.Errorf("key with ID %s already exists", key.ID())
+	}
+	return key, ks.putKey(key)
+}
+
+func (ks *backendStarknet) Export(id string, password string) ([]byte, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	key, err := ks.getByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsLedger() {
+		return nil, fmt.Errorf("cannot export ledger-backed key %s", id)
+	}
+	return starkkey.ToEncryptedJSON(key, password, ks.scryptParams)
+}
+
+func (ks *backendStarknet) EnsureKey() error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ids, err := ks.backend.List()
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		return nil
+	}
+	key, err := starkkey.New()
+	if err != nil {
+		return err
+	}
+	ks.lggr.Infof("Created StarkNet key with ID %s", key.ID())
+	return ks.putKey(key)
+}
+
+// Watch is not supported for a backend-backed StarkNet keystore: the whole point of
+// configuring an external backend (OS keychain, pass, ...) is that it, not a directory of
+// plaintext-adjacent keyfiles, is the source of truth for key material.
+func (ks *backendStarknet) Watch(ctx context.Context, dir string, passphrase PassphraseFunc) error {
+	return fmt.Errorf("Watch is not supported for a backend-backed StarkNet keystore")
+}
+
+func (ks *backendStarknet) getByID(id string) (starkkey.Key, error) {
+	encoded, err := ks.backend.Get(id)
+	if err != nil {
+		return starkkey.Key{}, err
+	}
+	key, err := decodeBackendKey(encoded, ks.passphrase)
+	if err != nil {
+		return starkkey.Key{}, errors.Wrap(err, "StarkNetKeyStore#getByID failed to decode key")
+	}
+	return key, nil
+}
+
+func (ks *backendStarknet) putKey(key starkkey.Key) error {
+	encoded, err := encodeBackendKey(key, ks.passphrase, ks.scryptParams)
+	if err != nil {
+		return err
+	}
+	return ks.backend.Put(key.ID(), encoded)
+}
@@ -0,0 +1,78 @@
+package keystore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func newTestBackendStarknet(t *testing.T) StarkNet {
+	t.Helper()
+	return NewStarkNet(NewMemoryBackend(), "test-passphrase", utils.FastScryptParams, logger.Test(t))
+}
+
+func TestBackendStarknet_Ledger_RoundTrip(t *testing.T) {
+	ks := newTestBackendStarknet(t)
+	pub := big.NewInt(424242)
+
+	created, err := ks.AddLedger("44'/9004'/0'/0/0", pub)
+	require.NoError(t, err)
+	assert.True(t, created.IsLedger())
+
+	record, err := ks.Get(created.ID())
+	require.NoError(t, err)
+	assert.True(t, record.IsLedger())
+	assert.Equal(t, "44'/9004'/0'/0/0", record.LedgerPath())
+	assert.NotNil(t, record.Metadata.AccountAddress, "Ledger-backed keys must still get an account address derived")
+
+	all, err := ks.GetAll()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, created.ID(), all[0].ID())
+}
+
+func TestBackendStarknet_Export_RejectsLedgerKey(t *testing.T) {
+	ks := newTestBackendStarknet(t)
+	created, err := ks.AddLedger("44'/9004'/0'/0/0", big.NewInt(1))
+	require.NoError(t, err)
+
+	_, err = ks.Export(created.ID(), "some-password")
+	assert.Error(t, err)
+}
+
+func TestBackendStarknet_CreateMulti_PersistsAcrossInstances(t *testing.T) {
+	backend := NewMemoryBackend()
+	pubs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	first := NewStarkNet(backend, "test-passphrase", utils.FastScryptParams, logger.Test(t))
+	multi, err := first.CreateMulti(2, pubs)
+	require.NoError(t, err)
+
+	// A second keystore instance over the same backend (simulating a process restart) must
+	// still be able to look the multisig composite up: CreateMulti persists it, it isn't only
+	// cached in the first instance's in-memory map.
+	second := NewStarkNet(backend, "test-passphrase", utils.FastScryptParams, logger.Test(t))
+	reloaded, err := second.GetMulti(multi.ID())
+	require.NoError(t, err)
+	assert.Equal(t, multi, reloaded)
+
+	// GetAll must not surface the multisig composite as if it were a single key.
+	all, err := second.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	require.NoError(t, second.DeleteMulti(multi.ID()))
+	_, err = second.GetMulti(multi.ID())
+	assert.Error(t, err)
+}
+
+func TestBackendStarknet_GetMulti_NotFound(t *testing.T) {
+	ks := newTestBackendStarknet(t)
+	_, err := ks.GetMulti("does-not-exist")
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	adapters "github.com/smartcontractkit/chainlink-relay/pkg/loop/adapters/starknet"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+)
+
+// LedgerStarkNetApp is the subset of the Ledger StarkNet app's APDU protocol this keystore
+// needs. It exists so tests can inject a fake implementation instead of talking to real
+// hardware over USB.
+type LedgerStarkNetApp interface {
+	// GetPublicKey derives and returns the stark-curve public key at path without prompting
+	// the user to confirm on-device.
+	GetPublicKey(path string) (*big.Int, error)
+	// SignHash asks the device to sign a pedersen hash with the key at path, prompting the
+	// user to confirm on-device.
+	SignHash(path string, hash *big.Int) (r, s *big.Int, err error)
+	// Close releases the underlying USB/HID handle.
+	Close() error
+}
+
+// LedgerHub abstracts over discovering and opening a connected Ledger device running the
+// StarkNet app, so tests can inject a fake transport.
+type LedgerHub interface {
+	OpenStarkNetApp() (LedgerStarkNetApp, error)
+}
+
+// StarknetLedgerSigner implements [loop.Keystore], delegating Sign to a Ledger device running
+// the StarkNet app rather than signing with a software-held scalar. It is used directly by
+// callers that only ever deal with Ledger-backed keys. StarknetLooppSigner does not use this
+// type: it services mixed software/Ledger keyrings through its own LedgerHub and
+// signWithLedger.
+type StarknetLedgerSigner struct {
+	StarkNet
+	hub LedgerHub
+}
+
+// NewStarknetLedgerSigner constructs a StarknetLedgerSigner. ks is used to look up the
+// derivation path and public key recorded for a given key ID via AddLedger.
+func NewStarknetLedgerSigner(ks StarkNet, hub LedgerHub) *StarknetLedgerSigner {
+	return &StarknetLedgerSigner{StarkNet: ks, hub: hub}
+}
+
+// Sign implements [loop.Keystore]. hash is expected to be the byte representation of a
+// big.Int pedersen hash; the return []byte encodes a starknet signature per
+// [adapters.SignatureFromBigInts].
+func (lk *StarknetLedgerSigner) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	k, err := lk.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		return nil, nil
+	}
+	x, y, err := signWithLedger(lk.hub, k.Key, new(big.Int).SetBytes(hash))
+	if err != nil {
+		return nil, err
+	}
+	sig, err := adapters.SignatureFromBigInts(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+// signWithLedger opens the StarkNet app on the first available Ledger device and signs hash
+// with the key at k's derivation path.
+func signWithLedger(hub LedgerHub, k starkkey.Key, hash *big.Int) (r, s *big.Int, err error) {
+	app, err := hub.OpenStarkNetApp()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening ledger starknet app: %w", err)
+	}
+	defer app.Close()
+
+	r, s, err = app.SignHash(k.LedgerPath(), hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error signing hash on ledger device: %w", err)
+	}
+	return r, s, nil
+}
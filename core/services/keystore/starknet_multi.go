@@ -0,0 +1,88 @@
+package keystore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/caigo"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+)
+
+// partialSignature is one constituent's contribution to a StarkNet multisig.
+type partialSignature struct {
+	signerIndex int
+	r, s        *big.Int
+}
+
+// encodeMultiSignature concatenates partials as (signer_index, r, s) tuples behind a 4-byte
+// big-endian count prefix, the wire format described for adapters.MultiSignature. Decode on
+// StarkNetKeystoreAdapter stays single-sig: account-abstraction contracts that verify
+// off-chain aggregation on-chain expect the submitter, not this keystore, to assemble final
+// calldata from these partials.
+func encodeMultiSignature(partials []partialSignature) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(len(partials)))
+	for _, p := range partials {
+		var idx [4]byte
+		binary.BigEndian.PutUint32(idx[:], uint32(p.signerIndex))
+		buf = append(buf, idx[:]...)
+		buf = append(buf, lenPrefixed(p.r.Bytes())...)
+		buf = append(buf, lenPrefixed(p.s.Bytes())...)
+	}
+	return buf
+}
+
+func lenPrefixed(b []byte) []byte {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(b)))
+	return append(l[:], b...)
+}
+
+// signMulti collects partial signatures over hash from whichever constituents of multi this
+// node holds a local key for (software or Ledger-backed) and encodes them as a
+// MultiSignature. It returns an error if signing for any held constituent fails, or if no
+// partial is produced at all (no constituent is held locally, or every held one is
+// Ledger-backed and no LedgerHub is configured); callers holding fewer than multi.Threshold
+// partials are expected to gather the rest out-of-band before submission.
+func (lk *StarknetLooppSigner) signMulti(multi starkkey.MultiKey, hash []byte) ([]byte, error) {
+	if hash == nil {
+		return nil, nil
+	}
+	starkHash := new(big.Int).SetBytes(hash)
+
+	var partials []partialSignature
+	for idx, pub := range multi.PublicKeys {
+		k, err := lk.Get(starkkey.IDFromPublicKey(pub))
+		if err != nil {
+			continue // this node doesn't hold this constituent locally
+		}
+
+		var x, y *big.Int
+		if k.IsLedger() {
+			if lk.ledgerHub == nil {
+				// This node holds the constituent key itself but has no LedgerHub wired up to
+				// reach the device, so it can't actually contribute a partial. Log rather than
+				// silently under-signing: a caller collecting partials from Accounts() would
+				// otherwise see what looks like a complete set and submit it short a signer.
+				if lk.lggr != nil {
+					lk.lggr.Warnf("starknet keystore: skipping Ledger-backed constituent %d of multisig %s: no LedgerHub configured", idx, multi.ID())
+				}
+				continue
+			}
+			x, y, err = signWithLedger(lk.ledgerHub, k.Key, starkHash)
+		} else {
+			x, y, err = caigo.Curve.Sign(starkHash, k.ToPrivKey())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error producing partial multisig signature for signer %d: %w", idx, err)
+		}
+		partials = append(partials, partialSignature{signerIndex: idx, r: x, s: y})
+	}
+
+	if len(partials) == 0 {
+		return nil, fmt.Errorf("no local key held for any of the %d constituents of multisig %s", len(multi.PublicKeys), multi.ID())
+	}
+	return encodeMultiSignature(partials), nil
+}
@@ -0,0 +1,120 @@
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/smartcontractkit/chainlink-relay/pkg/loop"
+	adapters "github.com/smartcontractkit/chainlink-relay/pkg/loop/adapters/starknet"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/pb"
+)
+
+//go:generate protoc --go_out=. --go-grpc_out=. pb/starknet_signer.proto
+
+// NOTE: pb/starknet_signer.pb.go is currently a hand-written placeholder, not the output of
+// the go:generate directive above (this tree has no protoc available to run it). It's enough
+// to compile and unit-test RemoteSignerClient against a fake, but NewStarkNetRemoteLooppKeystore
+// is not yet wired to a real gRPC client: run the go:generate directive to produce real
+// generated types before dialing an actual remote signer process.
+
+// RemoteSignerClient is the subset of the generated StarkSignerClient gRPC stub this keystore
+// needs. Expressing it as an interface lets tests inject a fake instead of dialing a real HSM
+// or KMS-backed signer process.
+type RemoteSignerClient interface {
+	Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error)
+	ListAccounts(ctx context.Context, in *pb.ListAccountsRequest, opts ...grpc.CallOption) (*pb.AccountList, error)
+}
+
+// remoteStarkNetLooppKeystore implements [loop.Keystore] by forwarding Sign to an external
+// signer process over gRPC instead of holding the private scalar in node memory. It's used
+// when the scalar lives in an HSM or cloud KMS.
+type remoteStarkNetLooppKeystore struct {
+	client RemoteSignerClient
+}
+
+var _ loop.Keystore = &remoteStarkNetLooppKeystore{}
+
+// NewStarkNetRemoteLooppKeystore constructs a loop.Keystore that forwards Sign(ctx, id, hash)
+// to client over gRPC. The wire format of the returned signature is unchanged from the local
+// signer, so StarkNetKeystoreAdapter's Decode works unmodified: callers of caigo.Keystore
+// don't care whether the underlying signer is local or remote.
+func NewStarkNetRemoteLooppKeystore(client RemoteSignerClient) loop.Keystore {
+	return &remoteStarkNetLooppKeystore{client: client}
+}
+
+// Sign implements [loop.Keystore].
+func (rk *remoteStarkNetLooppKeystore) Sign(ctx context.Context, id string, hash []byte) ([]byte, error) {
+	// loopp spec requires passing nil hash to check existence of id. This probe is answered
+	// here without a round-trip to the HSM/KMS process: it returns nil, nil for any id, so
+	// unlike the local StarknetLooppSigner it does not confirm that the remote signer holds id.
+	if hash == nil {
+		return nil, nil
+	}
+
+	resp, err := rk.client.Sign(ctx, &pb.SignRequest{KeyId: id, Hash: hash})
+	if err != nil {
+		return nil, fmt.Errorf("error calling remote starknet signer: %w", err)
+	}
+
+	x := new(big.Int).SetBytes(resp.R)
+	y := new(big.Int).SetBytes(resp.S)
+	sig, err := adapters.SignatureFromBigInts(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+// Accounts implements [loop.Keystore].
+func (rk *remoteStarkNetLooppKeystore) Accounts(ctx context.Context) ([]string, error) {
+	resp, err := rk.client.ListAccounts(ctx, &pb.ListAccountsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Accounts, nil
+}
+
+// RemoteSignerDialConfig configures the mutual-TLS gRPC connection used to reach a remote
+// StarkNet signer.
+type RemoteSignerDialConfig struct {
+	// Addr is the host:port of the remote signer.
+	Addr string
+	// TLSConfig authenticates both sides of the connection; it must present this node's
+	// client certificate and trust the remote signer's CA.
+	TLSConfig credentials.TransportCredentials
+	// MinBackoff/MaxBackoff bound the reconnect backoff. Remote-signed StarkNet jobs are
+	// typically long-running OCR jobs, so the connection needs to survive transient network
+	// blips to the signer rather than failing a round outright.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DialRemoteSigner opens a mutual-TLS gRPC connection to a remote StarkNet signer with a
+// reconnect/backoff policy suitable for a long-running OCR job.
+func DialRemoteSigner(cfg RemoteSignerDialConfig) (*grpc.ClientConn, error) {
+	minBackoff := cfg.MinBackoff
+	if minBackoff == 0 {
+		minBackoff = time.Second
+	}
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = 30 * time.Second
+	}
+	return grpc.Dial(cfg.Addr,
+		grpc.WithTransportCredentials(cfg.TLSConfig),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  minBackoff,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   maxBackoff,
+			},
+		}),
+	)
+}
@@ -0,0 +1,77 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	adapters "github.com/smartcontractkit/chainlink-relay/pkg/loop/adapters/starknet"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/pb"
+)
+
+// fakeRemoteSignerClient is an in-memory RemoteSignerClient used to test
+// remoteStarkNetLooppKeystore without dialing a real HSM/KMS process.
+type fakeRemoteSignerClient struct {
+	signCalled bool
+	signResp   *pb.SignResponse
+	signErr    error
+	accounts   []string
+	listErr    error
+}
+
+func (f *fakeRemoteSignerClient) Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error) {
+	f.signCalled = true
+	return f.signResp, f.signErr
+}
+
+func (f *fakeRemoteSignerClient) ListAccounts(ctx context.Context, in *pb.ListAccountsRequest, opts ...grpc.CallOption) (*pb.AccountList, error) {
+	return &pb.AccountList{Accounts: f.accounts}, f.listErr
+}
+
+func TestRemoteStarkNetLooppKeystore_Sign_NilHashDoesNotCallRemote(t *testing.T) {
+	client := &fakeRemoteSignerClient{}
+	ks := NewStarkNetRemoteLooppKeystore(client)
+
+	sig, err := ks.Sign(context.Background(), "key-id", nil)
+	require.NoError(t, err)
+	assert.Nil(t, sig)
+	assert.False(t, client.signCalled, "a nil-hash existence probe must not round-trip to the remote signer")
+}
+
+func TestRemoteStarkNetLooppKeystore_Sign(t *testing.T) {
+	x, y := big.NewInt(111), big.NewInt(222)
+	client := &fakeRemoteSignerClient{signResp: &pb.SignResponse{R: x.Bytes(), S: y.Bytes()}}
+	ks := NewStarkNetRemoteLooppKeystore(client)
+
+	sig, err := ks.Sign(context.Background(), "key-id", big.NewInt(999).Bytes())
+	require.NoError(t, err)
+	assert.True(t, client.signCalled)
+
+	want, err := adapters.SignatureFromBigInts(x, y)
+	require.NoError(t, err)
+	wantBytes, err := want.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, wantBytes, sig)
+}
+
+func TestRemoteStarkNetLooppKeystore_Sign_RemoteError(t *testing.T) {
+	client := &fakeRemoteSignerClient{signErr: errors.New("boom")}
+	ks := NewStarkNetRemoteLooppKeystore(client)
+
+	_, err := ks.Sign(context.Background(), "key-id", big.NewInt(1).Bytes())
+	assert.Error(t, err)
+}
+
+func TestRemoteStarkNetLooppKeystore_Accounts(t *testing.T) {
+	client := &fakeRemoteSignerClient{accounts: []string{"0x1", "0x2"}}
+	ks := NewStarkNetRemoteLooppKeystore(client)
+
+	accounts, err := ks.Accounts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x1", "0x2"}, accounts)
+}
@@ -0,0 +1,153 @@
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/starkkey"
+)
+
+// PassphraseFunc returns the decryption password for the keyfile at path, found during a
+// StarkNet directory watch.
+type PassphraseFunc func(path string) (string, error)
+
+// starkkeyFileCache mirrors a directory of scrypt-encrypted StarkNet keyfiles into the
+// keystore: it diffs the directory on fsnotify events and calls safeAddKey/safeRemoveKey
+// under the keystore's existing lock as files are created, updated, or removed. This lets
+// operators drop keyfiles projected from a Kubernetes sealed secret into a mounted directory
+// and have them picked up without a process restart. The approach borrows from go-ethereum's
+// accounts/keystore/watch.go and file_cache.go.
+type starkkeyFileCache struct {
+	ks         *starknet
+	dir        string
+	passphrase PassphraseFunc
+
+	mu       sync.Mutex
+	fileToID map[string]string // tracks which key ID a file currently contributes
+}
+
+func newStarkkeyFileCache(ks *starknet, dir string, passphrase PassphraseFunc) *starkkeyFileCache {
+	return &starkkeyFileCache{
+		ks:         ks,
+		dir:        dir,
+		passphrase: passphrase,
+		fileToID:   map[string]string{},
+	}
+}
+
+func (c *starkkeyFileCache) start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("starknet keystore: failed to create file watcher: %w", err)
+	}
+	if err = watcher.Add(c.dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("starknet keystore: failed to watch %s: %w", c.dir, err)
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		watcher.Close()
+		return fmt.Errorf("starknet keystore: failed to read %s: %w", c.dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			c.addFile(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+
+	go c.loop(ctx, watcher)
+	return nil
+}
+
+func (c *starkkeyFileCache) loop(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			switch {
+			case event.Op&(fsnotify.Create|fsnotify.Write) != 0:
+				c.addFile(event.Name)
+			case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+				c.removeFile(event.Name)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			c.ks.logger.Errorf("starknet keystore: file watcher error watching %s: %v", c.dir, err)
+		}
+	}
+}
+
+func (c *starkkeyFileCache) addFile(path string) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		c.ks.logger.Errorf("starknet keystore: failed to read keyfile %s: %v", path, err)
+		return
+	}
+	passphrase, err := c.passphrase(path)
+	if err != nil {
+		c.ks.logger.Errorf("starknet keystore: failed to obtain passphrase for %s: %v", path, err)
+		return
+	}
+	key, err := starkkey.FromEncryptedJSON(raw, passphrase)
+	if err != nil {
+		c.ks.logger.Errorf("starknet keystore: failed to decrypt keyfile %s: %v", path, err)
+		return
+	}
+
+	c.mu.Lock()
+	if oldID, found := c.fileToID[path]; found && oldID != key.ID() {
+		c.mu.Unlock()
+		c.removeKey(oldID)
+		c.mu.Lock()
+	}
+	c.fileToID[path] = key.ID()
+	c.mu.Unlock()
+
+	c.ks.lock.Lock()
+	err = c.ks.safeAddKey(key)
+	c.ks.lock.Unlock()
+	if err != nil {
+		c.ks.logger.Errorf("starknet keystore: failed to add key from %s: %v", path, err)
+		return
+	}
+	c.ks.logger.Infof("audit: loaded StarkNet key %s from watched file %s", key.ID(), path)
+}
+
+func (c *starkkeyFileCache) removeFile(path string) {
+	c.mu.Lock()
+	id, found := c.fileToID[path]
+	delete(c.fileToID, path)
+	c.mu.Unlock()
+	if found {
+		c.removeKey(id)
+	}
+}
+
+func (c *starkkeyFileCache) removeKey(id string) {
+	c.ks.lock.Lock()
+	key, found := c.ks.keyRing.StarkNet[id]
+	if !found {
+		c.ks.lock.Unlock()
+		return
+	}
+	err := c.ks.safeRemoveKey(key)
+	c.ks.lock.Unlock()
+	if err != nil {
+		c.ks.logger.Errorf("starknet keystore: failed to deactivate key %s: %v", id, err)
+		return
+	}
+	c.ks.logger.Infof("audit: deactivated StarkNet key %s removed from watched directory", id)
+}